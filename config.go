@@ -0,0 +1,111 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/afiskon/promtail-client/promtail"
+	"gopkg.in/yaml.v3"
+)
+
+// configFile points at the YAML/JSON file initLoki loads its LokiConfig
+// from. It's reloaded on SIGHUP or whenever its mtime changes (promtail
+// transport only, see watchConfigReload in loki.go).
+var configFile = flag.String("config.file", "", "path to the Loki client config file (YAML or JSON)")
+
+// LokiConfig is the on-disk shape of promtail.ClientConfig plus the fields
+// this app needs beyond what promtail-client exposes natively.
+type LokiConfig struct {
+	PushURL            string            `yaml:"push_url" json:"push_url"`
+	Labels             string            `yaml:"labels" json:"labels"`
+	BatchWait          time.Duration     `yaml:"batch_wait" json:"batch_wait"`
+	BatchEntriesNumber int               `yaml:"batch_entries_number" json:"batch_entries_number"`
+	SendLevel          string            `yaml:"send_level" json:"send_level"`
+	PrintLevel         string            `yaml:"print_level" json:"print_level"`
+	TenantID           string            `yaml:"tenant_id" json:"tenant_id"`
+	ExtraLabels        map[string]string `yaml:"extra_labels" json:"extra_labels"`
+}
+
+func defaultLokiConfig() LokiConfig {
+	return LokiConfig{
+		PushURL:            "http://localhost:3100/loki/api/v1/push",
+		Labels:             "{app=\"go-crud-server\"}",
+		BatchWait:          5 * time.Second,
+		BatchEntriesNumber: 10000,
+		SendLevel:          "INFO",
+		PrintLevel:         "ERROR",
+	}
+}
+
+func parsePromtailLevel(s string) promtail.LogLevel {
+	switch strings.ToUpper(s) {
+	case "DEBUG":
+		return promtail.DEBUG
+	case "WARN", "WARNING":
+		return promtail.WARN
+	case "ERROR":
+		return promtail.ERROR
+	default:
+		return promtail.INFO
+	}
+}
+
+// mergedLabels appends ExtraLabels, plus TenantID as a "tenant_id" label, to
+// Labels. promtail-client's HTTP push path has no hook for a tenant header
+// (unlike Loki's gRPC push, see grpcSink), so a label is the only way to get
+// TenantID onto the stream at all.
+func (c LokiConfig) mergedLabels() string {
+	extra := make(map[string]string, len(c.ExtraLabels)+1)
+	for k, v := range c.ExtraLabels {
+		extra[k] = v
+	}
+	if c.TenantID != "" {
+		extra["tenant_id"] = c.TenantID
+	}
+
+	labels := c.Labels
+	if len(extra) > 0 {
+		labels = strings.TrimSuffix(labels, "}")
+		for k, v := range extra {
+			labels += fmt.Sprintf(", %s=\"%s\"", k, v)
+		}
+		labels += "}"
+	}
+	return labels
+}
+
+// toClientConfig builds the promtail-client config this app's LokiConfig
+// maps to, with ExtraLabels/TenantID folded into Labels via mergedLabels.
+func (c LokiConfig) toClientConfig() promtail.ClientConfig {
+	return promtail.ClientConfig{
+		PushURL:            c.PushURL,
+		Labels:             c.mergedLabels(),
+		BatchWait:          c.BatchWait,
+		BatchEntriesNumber: c.BatchEntriesNumber,
+		SendLevel:          parsePromtailLevel(c.SendLevel),
+		PrintLevel:         parsePromtailLevel(c.PrintLevel),
+	}
+}
+
+// loadConfigFile reads path as YAML or JSON based on its extension, falling
+// back to YAML for anything else since it's a superset of JSON.
+func loadConfigFile(path string) (LokiConfig, error) {
+	cfg := defaultLokiConfig()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cfg, err
+	}
+
+	if strings.ToLower(filepath.Ext(path)) == ".json" {
+		err = json.Unmarshal(data, &cfg)
+	} else {
+		err = yaml.Unmarshal(data, &cfg)
+	}
+	return cfg, err
+}
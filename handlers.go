@@ -0,0 +1,138 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Galadon123/Monitoring-go-app-CRUD-with-Grafana-Loki/internal/store"
+)
+
+// itemHandlers holds the dependencies the CRUD routes need, so the store
+// implementation can be swapped (Mongo in production, in-memory in tests)
+// without touching the handlers themselves.
+type itemHandlers struct {
+	store store.Store
+}
+
+func newItemHandlers(s store.Store) *itemHandlers {
+	return &itemHandlers{store: s}
+}
+
+func (h *itemHandlers) create(c *gin.Context) {
+	var item store.Item
+	if err := c.ShouldBindJSON(&item); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.store.Create(c.Request.Context(), &item); err != nil {
+		logToLokiLevel(LevelError, "failed to create item", logFields{"request_id": requestIDFrom(c), "error": err.Error()})
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create item"})
+		return
+	}
+
+	logToLokiLevel(LevelInfo, "created item", logFields{"request_id": requestIDFrom(c), "item_id": item.ID})
+	c.JSON(http.StatusCreated, item)
+}
+
+func (h *itemHandlers) get(c *gin.Context) {
+	id := c.Param("id")
+
+	item, err := h.store.Get(c.Request.Context(), id)
+	if errors.Is(err, store.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "item not found"})
+		return
+	}
+	if err != nil {
+		logToLokiLevel(LevelError, "failed to get item", logFields{"request_id": requestIDFrom(c), "item_id": id, "error": err.Error()})
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get item"})
+		return
+	}
+
+	logToLokiLevel(LevelInfo, "got item", logFields{"request_id": requestIDFrom(c), "item_id": id})
+	c.JSON(http.StatusOK, item)
+}
+
+func (h *itemHandlers) update(c *gin.Context) {
+	id := c.Param("id")
+
+	var item store.Item
+	if err := c.ShouldBindJSON(&item); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.store.Update(c.Request.Context(), id, &item); errors.Is(err, store.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "item not found"})
+		return
+	} else if err != nil {
+		logToLokiLevel(LevelError, "failed to update item", logFields{"request_id": requestIDFrom(c), "item_id": id, "error": err.Error()})
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update item"})
+		return
+	}
+
+	logToLokiLevel(LevelInfo, "updated item", logFields{"request_id": requestIDFrom(c), "item_id": id})
+	c.JSON(http.StatusOK, item)
+}
+
+func (h *itemHandlers) delete(c *gin.Context) {
+	id := c.Param("id")
+
+	if err := h.store.Delete(c.Request.Context(), id); errors.Is(err, store.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "item not found"})
+		return
+	} else if err != nil {
+		logToLokiLevel(LevelError, "failed to delete item", logFields{"request_id": requestIDFrom(c), "item_id": id, "error": err.Error()})
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete item"})
+		return
+	}
+
+	logToLokiLevel(LevelInfo, "deleted item", logFields{"request_id": requestIDFrom(c), "item_id": id})
+	c.JSON(http.StatusOK, gin.H{"message": "item deleted successfully"})
+}
+
+func (h *itemHandlers) list(c *gin.Context) {
+	params := store.ListParams{Query: c.Query("q")}
+
+	if limit, err := strconv.ParseInt(c.Query("limit"), 10, 64); err == nil && limit > 0 {
+		params.Limit = limit
+	}
+	if offset, err := strconv.ParseInt(c.Query("offset"), 10, 64); err == nil && offset > 0 {
+		params.Offset = offset
+	}
+
+	items, err := h.store.List(c.Request.Context(), params)
+	if err != nil {
+		logToLokiLevel(LevelError, "failed to list items", logFields{"request_id": requestIDFrom(c), "error": err.Error()})
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list items"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"items": items})
+}
+
+// newStore picks a MongoDB-backed store when MONGO_URI is set, falling back
+// to an in-memory one otherwise so the API stays usable without a database.
+func newStore(ctx context.Context) store.Store {
+	uri := os.Getenv("MONGO_URI")
+	if uri == "" {
+		return store.NewMemoryStore()
+	}
+
+	dbName := os.Getenv("MONGO_DATABASE")
+	if dbName == "" {
+		dbName = "crud"
+	}
+
+	s, err := store.NewMongoStore(ctx, uri, dbName, "items")
+	if err != nil {
+		log.Fatalf("failed to connect to MongoDB at %s: %v", uri, err)
+	}
+	return s
+}
@@ -0,0 +1,127 @@
+package store
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MemoryStore is a process-local Store backed by a map. It's used when no
+// Mongo URI is configured, so the API stays usable without a database.
+type MemoryStore struct {
+	mu     sync.RWMutex
+	items  map[string]*Item
+	nextID int64
+}
+
+// NewMemoryStore returns an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{items: make(map[string]*Item)}
+}
+
+func (s *MemoryStore) Create(ctx context.Context, item *Item) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.nextID++
+	item.ID = strconv.FormatInt(s.nextID, 10)
+	now := time.Now().UTC()
+	item.CreatedAt = now
+	item.UpdatedAt = now
+
+	stored := *item
+	s.items[item.ID] = &stored
+	return nil
+}
+
+func (s *MemoryStore) Get(ctx context.Context, id string) (*Item, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	item, ok := s.items[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	cp := *item
+	return &cp, nil
+}
+
+func (s *MemoryStore) Update(ctx context.Context, id string, item *Item) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, ok := s.items[id]
+	if !ok {
+		return ErrNotFound
+	}
+
+	item.ID = id
+	item.CreatedAt = existing.CreatedAt
+	item.UpdatedAt = time.Now().UTC()
+
+	stored := *item
+	s.items[id] = &stored
+	return nil
+}
+
+func (s *MemoryStore) Delete(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.items[id]; !ok {
+		return ErrNotFound
+	}
+	delete(s.items, id)
+	return nil
+}
+
+func (s *MemoryStore) List(ctx context.Context, params ListParams) ([]*Item, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	limit := params.Limit
+	if limit <= 0 {
+		limit = DefaultListLimit
+	}
+	offset := params.Offset
+	if offset < 0 {
+		offset = 0
+	}
+
+	matched := make([]*Item, 0, len(s.items))
+	for _, item := range s.items {
+		if params.Query != "" && !strings.Contains(strings.ToLower(item.Name), strings.ToLower(params.Query)) {
+			continue
+		}
+		cp := *item
+		matched = append(matched, &cp)
+	}
+
+	sortByID(matched)
+
+	if offset >= int64(len(matched)) {
+		return []*Item{}, nil
+	}
+	end := offset + limit
+	if end > int64(len(matched)) {
+		end = int64(len(matched))
+	}
+	return matched[offset:end], nil
+}
+
+// sortByID keeps List's output stable across calls; items are keyed by a
+// monotonically increasing numeric ID so a plain numeric sort works.
+func sortByID(items []*Item) {
+	for i := 1; i < len(items); i++ {
+		for j := i; j > 0; j-- {
+			a, _ := strconv.ParseInt(items[j-1].ID, 10, 64)
+			b, _ := strconv.ParseInt(items[j].ID, 10, 64)
+			if a <= b {
+				break
+			}
+			items[j-1], items[j] = items[j], items[j-1]
+		}
+	}
+}
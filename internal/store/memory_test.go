@@ -0,0 +1,101 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestMemoryStoreCRUD(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStore()
+
+	item := &Item{Name: "widget", Description: "a widget"}
+	if err := s.Create(ctx, item); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if item.ID == "" {
+		t.Fatal("Create did not assign an ID")
+	}
+
+	got, err := s.Get(ctx, item.ID)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.Name != "widget" {
+		t.Fatalf("Get returned name %q, want %q", got.Name, "widget")
+	}
+
+	update := &Item{Name: "gadget", Description: "now a gadget"}
+	if err := s.Update(ctx, item.ID, update); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	got, err = s.Get(ctx, item.ID)
+	if err != nil {
+		t.Fatalf("Get after update: %v", err)
+	}
+	if got.Name != "gadget" {
+		t.Fatalf("Get after update returned name %q, want %q", got.Name, "gadget")
+	}
+
+	if err := s.Delete(ctx, item.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := s.Get(ctx, item.ID); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Get after delete: got err %v, want ErrNotFound", err)
+	}
+	if err := s.Update(ctx, item.ID, update); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Update of deleted item: got err %v, want ErrNotFound", err)
+	}
+	if err := s.Delete(ctx, item.ID); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Delete of already-deleted item: got err %v, want ErrNotFound", err)
+	}
+}
+
+func TestMemoryStoreList(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStore()
+
+	names := []string{"apple", "banana", "apricot", "cherry"}
+	for _, name := range names {
+		if err := s.Create(ctx, &Item{Name: name}); err != nil {
+			t.Fatalf("Create(%q): %v", name, err)
+		}
+	}
+
+	all, err := s.List(ctx, ListParams{})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(all) != len(names) {
+		t.Fatalf("List returned %d items, want %d", len(all), len(names))
+	}
+
+	filtered, err := s.List(ctx, ListParams{Query: "ap"})
+	if err != nil {
+		t.Fatalf("List with query: %v", err)
+	}
+	if len(filtered) != 2 {
+		t.Fatalf("List(q=ap) returned %d items, want 2", len(filtered))
+	}
+
+	page, err := s.List(ctx, ListParams{Limit: 2, Offset: 1})
+	if err != nil {
+		t.Fatalf("List with pagination: %v", err)
+	}
+	if len(page) != 2 {
+		t.Fatalf("List(limit=2,offset=1) returned %d items, want 2", len(page))
+	}
+	if page[0].ID != all[1].ID {
+		t.Fatalf("List(offset=1) started at ID %q, want %q", page[0].ID, all[1].ID)
+	}
+
+	// A negative offset/limit (e.g. from a client-supplied ?offset=-1) must
+	// not panic with a negative slice index.
+	if _, err := s.List(ctx, ListParams{Offset: -1}); err != nil {
+		t.Fatalf("List(offset=-1): %v", err)
+	}
+	if _, err := s.List(ctx, ListParams{Limit: -1}); err != nil {
+		t.Fatalf("List(limit=-1): %v", err)
+	}
+}
@@ -0,0 +1,171 @@
+package store
+
+import (
+	"context"
+	"regexp"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoStore is a Store backed by a MongoDB collection.
+type MongoStore struct {
+	collection *mongo.Collection
+}
+
+// NewMongoStore connects to uri and returns a MongoStore backed by
+// dbName.collName. The connection is verified with a Ping before returning.
+func NewMongoStore(ctx context.Context, uri, dbName, collName string) (*MongoStore, error) {
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+	if err != nil {
+		return nil, err
+	}
+	if err := client.Ping(ctx, nil); err != nil {
+		return nil, err
+	}
+	return &MongoStore{collection: client.Database(dbName).Collection(collName)}, nil
+}
+
+// mongoDoc mirrors Item but with a real ObjectID for _id, since bson can't
+// unmarshal an ObjectID straight into a string field.
+type mongoDoc struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty"`
+	Name        string             `bson:"name"`
+	Description string             `bson:"description"`
+	CreatedAt   time.Time          `bson:"created_at"`
+	UpdatedAt   time.Time          `bson:"updated_at"`
+}
+
+func (d mongoDoc) toItem() *Item {
+	return &Item{
+		ID:          d.ID.Hex(),
+		Name:        d.Name,
+		Description: d.Description,
+		CreatedAt:   d.CreatedAt,
+		UpdatedAt:   d.UpdatedAt,
+	}
+}
+
+func (s *MongoStore) Create(ctx context.Context, item *Item) error {
+	now := time.Now().UTC()
+	doc := mongoDoc{
+		ID:          primitive.NewObjectID(),
+		Name:        item.Name,
+		Description: item.Description,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	if _, err := s.collection.InsertOne(ctx, doc); err != nil {
+		return err
+	}
+
+	item.ID = doc.ID.Hex()
+	item.CreatedAt = now
+	item.UpdatedAt = now
+	return nil
+}
+
+func (s *MongoStore) Get(ctx context.Context, id string) (*Item, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+
+	var doc mongoDoc
+	err = s.collection.FindOne(ctx, bson.M{"_id": oid}).Decode(&doc)
+	if err == mongo.ErrNoDocuments {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return doc.toItem(), nil
+}
+
+func (s *MongoStore) Update(ctx context.Context, id string, item *Item) error {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return ErrNotFound
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"name":        item.Name,
+			"description": item.Description,
+			"updated_at":  time.Now().UTC(),
+		},
+	}
+
+	// FindOneAndUpdate (returning the post-update document) lets us hand
+	// back ID/CreatedAt/UpdatedAt the same way MemoryStore.Update does,
+	// without a second round trip to re-read the row.
+	var doc mongoDoc
+	err = s.collection.FindOneAndUpdate(ctx, bson.M{"_id": oid}, update,
+		options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&doc)
+	if err == mongo.ErrNoDocuments {
+		return ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	*item = *doc.toItem()
+	return nil
+}
+
+func (s *MongoStore) Delete(ctx context.Context, id string) error {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return ErrNotFound
+	}
+
+	res, err := s.collection.DeleteOne(ctx, bson.M{"_id": oid})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (s *MongoStore) List(ctx context.Context, params ListParams) ([]*Item, error) {
+	limit := params.Limit
+	if limit <= 0 {
+		limit = DefaultListLimit
+	}
+
+	filter := bson.M{}
+	if params.Query != "" {
+		// Match MemoryStore's literal substring semantics: escape regex
+		// metacharacters so a query like "3.5" can't be (mis)read as a
+		// pattern, and so a crafted q can't trigger catastrophic backtracking
+		// on the server.
+		filter["name"] = bson.M{"$regex": regexp.QuoteMeta(params.Query), "$options": "i"}
+	}
+
+	opts := options.Find().
+		SetLimit(limit).
+		SetSkip(params.Offset).
+		SetSort(bson.M{"_id": 1})
+
+	cursor, err := s.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	items := make([]*Item, 0)
+	for cursor.Next(ctx) {
+		var doc mongoDoc
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, err
+		}
+		items = append(items, doc.toItem())
+	}
+	return items, cursor.Err()
+}
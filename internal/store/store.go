@@ -0,0 +1,46 @@
+// Package store defines the persistence interface for CRUD items and ships
+// a MongoDB-backed implementation plus an in-memory one for local/dev use.
+package store
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrNotFound is returned by Get/Update/Delete when no item matches the
+// given ID.
+var ErrNotFound = errors.New("item not found")
+
+// Item is the persisted CRUD resource. ID is the hex-encoded Mongo
+// ObjectID (or, for the in-memory store, a locally generated ID) so it can
+// round-trip through JSON as a plain string.
+type Item struct {
+	ID          string    `bson:"_id,omitempty" json:"id,omitempty"`
+	Name        string    `bson:"name" json:"name" binding:"required"`
+	Description string    `bson:"description" json:"description"`
+	CreatedAt   time.Time `bson:"created_at" json:"created_at"`
+	UpdatedAt   time.Time `bson:"updated_at" json:"updated_at"`
+}
+
+// ListParams controls pagination and filtering for Store.List.
+type ListParams struct {
+	Limit  int64 // 0 means "use the store's default page size"
+	Offset int64
+	Query  string // matched against Name, case-insensitive substring
+}
+
+// Store is the persistence contract the HTTP handlers depend on. Create
+// assigns and fills in item.ID; Update and Delete return ErrNotFound when id
+// doesn't exist.
+type Store interface {
+	Create(ctx context.Context, item *Item) error
+	Get(ctx context.Context, id string) (*Item, error)
+	Update(ctx context.Context, id string, item *Item) error
+	Delete(ctx context.Context, id string) error
+	List(ctx context.Context, params ListParams) ([]*Item, error)
+}
+
+// DefaultListLimit caps how many items List returns when params.Limit is 0
+// or negative.
+const DefaultListLimit = 50
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"crypto/rand"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LogLevel mirrors the promtail log levels so promtailSink.Log can route to
+// the matching Debugf/Infof/Warnf/Errorf call.
+type LogLevel int
+
+const (
+	LevelDebug LogLevel = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+func (l LogLevel) String() string {
+	switch l {
+	case LevelDebug:
+		return "debug"
+	case LevelInfo:
+		return "info"
+	case LevelWarn:
+		return "warn"
+	case LevelError:
+		return "error"
+	default:
+		return "info"
+	}
+}
+
+// minLogLevel is the minimum level that gets forwarded to Loki. It can be
+// raised/lowered with the LOG_LEVEL env var (debug|info|warn|error).
+var minLogLevel = parseLogLevel(os.Getenv("LOG_LEVEL"))
+
+func parseLogLevel(s string) LogLevel {
+	switch strings.ToLower(s) {
+	case "debug":
+		return LevelDebug
+	case "warn", "warning":
+		return LevelWarn
+	case "error":
+		return LevelError
+	default:
+		return LevelInfo
+	}
+}
+
+// logFields is the set of structured key/value pairs attached to a single
+// log line. Keys are expected to be flat so the resulting JSON stays easy to
+// query with LogQL's `| json` parser.
+type logFields map[string]interface{}
+
+// logToLokiLevel forwards msg and fields to the active LogSink at the given
+// level, dropping anything below minLogLevel before it ever reaches the
+// network.
+func logToLokiLevel(level LogLevel, msg string, fields logFields) {
+	if level < minLogLevel {
+		return
+	}
+
+	sink := getSink()
+	if sink == nil {
+		lokiPushFailuresTotal.Inc()
+		return
+	}
+
+	if err := sink.Log(level, msg, fields); err != nil {
+		lokiPushFailuresTotal.Inc()
+		log.Printf("failed to push log line: %v", err)
+	}
+}
+
+// newRequestID returns a short random hex ID used to correlate a request's
+// access log line with anything it logs downstream.
+func newRequestID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("%d", time.Now().UnixNano())
+	}
+	return fmt.Sprintf("%x", b)
+}
+
+const requestIDHeader = "X-Request-ID"
+const requestIDContextKey = "request_id"
+
+// requestLogger attaches a request ID to the context and response header,
+// then emits one structured log line per request with the fields Loki needs
+// to answer `| json | status>=500` style queries.
+func requestLogger() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(requestIDHeader)
+		if requestID == "" {
+			requestID = newRequestID()
+		}
+		c.Set(requestIDContextKey, requestID)
+		c.Header(requestIDHeader, requestID)
+
+		start := time.Now()
+		c.Next()
+
+		latencyMs := float64(time.Since(start)) / float64(time.Millisecond)
+		level := LevelInfo
+		if c.Writer.Status() >= 500 {
+			level = LevelError
+		} else if c.Writer.Status() >= 400 {
+			level = LevelWarn
+		}
+
+		logToLokiLevel(level, "handled request", logFields{
+			"method":     c.Request.Method,
+			"path":       c.Request.URL.Path,
+			"status":     c.Writer.Status(),
+			"latency_ms": latencyMs,
+			"client_ip":  c.ClientIP(),
+			"request_id": requestID,
+			"user_agent": c.Request.UserAgent(),
+		})
+	}
+}
+
+// requestIDFrom returns the request ID stashed in the context by
+// requestLogger, or "" if it hasn't run (e.g. in unit tests).
+func requestIDFrom(c *gin.Context) string {
+	id, _ := c.Get(requestIDContextKey)
+	s, _ := id.(string)
+	return s
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+const (
+	defaultFallbackLogFile = "loki-fallback.log"
+	defaultMaxSinkFailures = 5
+	defaultSinkRetryPeriod = 30 * time.Second
+)
+
+// initLoki builds the active LogSink: an HTTP (promtail) or gRPC primary
+// transport, selected via LOKI_TRANSPORT, wrapped in a supervisor that
+// diverts to a local rotating file once the primary starts failing.
+func initLoki() {
+	cfg := defaultLokiConfig()
+	if *configFile != "" {
+		loaded, err := loadConfigFile(*configFile)
+		if err != nil {
+			log.Fatalf("failed to load Loki config file %s: %v", *configFile, err)
+		}
+		cfg = loaded
+	}
+
+	primary, promtailPrimary, err := newPrimarySink(cfg)
+	if err != nil {
+		log.Fatalf("failed to create Loki sink: %v", err)
+	}
+
+	fallbackPath := os.Getenv("LOKI_FALLBACK_FILE")
+	if fallbackPath == "" {
+		fallbackPath = defaultFallbackLogFile
+	}
+	fallback := newFileSink(fallbackPath)
+
+	setSink(newSupervisingSink(primary, fallback, defaultMaxSinkFailures, defaultSinkRetryPeriod))
+
+	if *configFile != "" && promtailPrimary != nil {
+		go watchConfigReload(*configFile, promtailPrimary)
+	}
+}
+
+// newPrimarySink picks the transport based on LOKI_TRANSPORT (default
+// "http"). It also returns the *promtailSink when that's the transport in
+// use, since only it supports the config-file hot-reload below.
+func newPrimarySink(cfg LokiConfig) (LogSink, *promtailSink, error) {
+	switch os.Getenv("LOKI_TRANSPORT") {
+	case "grpc":
+		addr := os.Getenv("LOKI_GRPC_ADDR")
+		if addr == "" {
+			addr = "localhost:9095"
+		}
+		sink, err := newGRPCSink(addr, cfg.Labels, cfg.TenantID)
+		return sink, nil, err
+	default:
+		sink, err := newPromtailSink(cfg)
+		return sink, sink, err
+	}
+}
+
+// watchConfigReload reloads configFile into sink whenever a SIGHUP is
+// received or the file's mtime advances, whichever comes first.
+func watchConfigReload(path string, sink *promtailSink) {
+	sighup := make(chan os.Signal, 1)
+	signal.Notify(sighup, syscall.SIGHUP)
+
+	lastMod := time.Time{}
+	if info, err := os.Stat(path); err == nil {
+		lastMod = info.ModTime()
+	}
+
+	ticker := time.NewTicker(2 * time.Second)
+	defer ticker.Stop()
+
+	reload := func() {
+		cfg, err := loadConfigFile(path)
+		if err != nil {
+			log.Printf("Loki config reload: failed to read %s: %v", path, err)
+			return
+		}
+		if err := sink.applyConfig(cfg); err != nil {
+			log.Printf("Loki config reload: failed to apply new config: %v", err)
+			return
+		}
+		log.Printf("Loki config reloaded from %s", path)
+	}
+
+	for {
+		select {
+		case <-sighup:
+			reload()
+		case <-ticker.C:
+			info, err := os.Stat(path)
+			if err != nil {
+				continue
+			}
+			if info.ModTime().After(lastMod) {
+				lastMod = info.ModTime()
+				reload()
+			}
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// RED metrics for the CRUD API, labeled by method/route/status. route comes
+// from c.FullPath() (e.g. "/item/:id") so path params don't blow up
+// cardinality.
+var (
+	httpRequestsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "http_requests_total",
+			Help: "Total number of HTTP requests handled.",
+		},
+		[]string{"method", "route", "status"},
+	)
+
+	httpRequestDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "http_request_duration_seconds",
+			Help:    "HTTP request duration in seconds.",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"method", "route", "status"},
+	)
+
+	// lokiPushFailuresTotal counts log lines a LogSink's Log call returned an
+	// error for. promtail-client's Client interface doesn't surface
+	// per-send errors (Debugf/Infof/etc. are fire-and-forget), so the
+	// promtail sink can only report "no client configured" as a failure.
+	// It does NOT cover lines the supervising sink silently absorbed into
+	// its file fallback - see lokiSinkFallbackActive for that. On the
+	// default HTTP/promtail transport a Loki outage leaves this counter at 0
+	// and does not trigger the file fallback either, since promtailSink.Log
+	// returns nil whether or not the push succeeds; neither this counter nor
+	// lokiSinkFallbackActive reports that case.
+	lokiPushFailuresTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "loki_push_failures_total",
+		Help: "Number of log lines that could not be pushed to Loki.",
+	})
+
+	// lokiBatchSize tracks the configured BatchEntriesNumber rather than the
+	// client's actual in-flight batch, since promtail-client doesn't expose
+	// that either; it still tells you when a config reload changes batching
+	// behavior.
+	lokiBatchSize = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "loki_batch_size",
+		Help: "Configured Loki batch entries number.",
+	})
+
+	// lokiSinkFallbackActive is 1 while the supervising sink has diverted to
+	// its file fallback because the primary transport kept failing, 0
+	// otherwise. On the gRPC transport this is the signal to alert on for a
+	// Loki outage - the fallback absorbing writes successfully means
+	// lokiPushFailuresTotal stays flat even though the primary is down. The
+	// HTTP/promtail transport never reports a failed push, so there it stays 0.
+	lokiSinkFallbackActive = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "loki_sink_fallback_active",
+		Help: "1 while the Loki log sink is diverting to its local file fallback, 0 otherwise.",
+	})
+)
+
+// metricsMiddleware records one observation per request into the RED
+// metrics above.
+func metricsMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		status := strconv.Itoa(c.Writer.Status())
+
+		httpRequestsTotal.WithLabelValues(c.Request.Method, route, status).Inc()
+		httpRequestDuration.WithLabelValues(c.Request.Method, route, status).Observe(time.Since(start).Seconds())
+	}
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"encoding/json"
+	"sync/atomic"
+	"time"
+)
+
+// LogSink is the log transport abstraction. logToLokiLevel writes to
+// whatever sink is currently installed instead of talking to a specific
+// client directly, so the transport (HTTP push, gRPC push, local file) can
+// be swapped or wrapped with a fallback supervisor.
+type LogSink interface {
+	Log(level LogLevel, msg string, fields logFields) error
+	Shutdown()
+}
+
+// marshalLogLine builds the JSON payload (timestamp, level, message plus the
+// caller's fields) shared by every sink implementation, so each one emits
+// identical lines regardless of transport.
+func marshalLogLine(level LogLevel, msg string, fields logFields) (string, error) {
+	payload := logFields{
+		"timestamp": time.Now().UTC().Format(time.RFC3339Nano),
+		"level":     level.String(),
+		"message":   msg,
+	}
+	for k, v := range fields {
+		payload[k] = v
+	}
+
+	line, err := json.Marshal(payload)
+	return string(line), err
+}
+
+// sinkBox works around atomic.Value requiring every Store() to use the same
+// concrete type; LogSink implementations vary (promtailSink, grpcSink,
+// supervisingSink), so the box itself is the only thing ever stored.
+type sinkBox struct{ sink LogSink }
+
+var activeSink atomic.Value // holds sinkBox
+
+func getSink() LogSink {
+	box, _ := activeSink.Load().(sinkBox)
+	return box.sink
+}
+
+func setSink(s LogSink) {
+	activeSink.Store(sinkBox{sink: s})
+}
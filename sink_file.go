@@ -0,0 +1,46 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// fileSink writes log lines to a local, rotating file. It's the fallback
+// sink the supervisor diverts to when the primary transport is down, so
+// logs survive a Loki outage instead of being dropped.
+type fileSink struct {
+	mu     sync.Mutex
+	writer *lumberjack.Logger
+}
+
+func newFileSink(path string) *fileSink {
+	return &fileSink{
+		writer: &lumberjack.Logger{
+			Filename:   path,
+			MaxSize:    100, // megabytes
+			MaxBackups: 5,
+			MaxAge:     7, // days
+			Compress:   true,
+		},
+	}
+}
+
+func (s *fileSink) Log(level LogLevel, msg string, fields logFields) error {
+	line, err := marshalLogLine(level, msg, fields)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err = fmt.Fprintln(s.writer, line)
+	return err
+}
+
+func (s *fileSink) Shutdown() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.writer.Close()
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"errors"
+	"sync/atomic"
+
+	"github.com/afiskon/promtail-client/promtail"
+)
+
+// promtailSink is the original HTTP-push transport, backed by
+// afiskon/promtail-client. applyConfig hot-swaps the underlying client so
+// the SIGHUP/mtime watcher in loki.go can reload it without a restart.
+type promtailSink struct {
+	client atomic.Value // promtail.Client
+}
+
+func newPromtailSink(cfg LokiConfig) (*promtailSink, error) {
+	s := &promtailSink{}
+	if err := s.applyConfig(cfg); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// applyConfig builds a new client from cfg, publishes it so new log calls
+// pick it up immediately, then shuts the previous client down so whatever
+// it had buffered gets flushed instead of dropped.
+func (s *promtailSink) applyConfig(cfg LokiConfig) error {
+	newClient, err := promtail.NewClientProto(cfg.toClientConfig())
+	if err != nil {
+		return err
+	}
+
+	old, _ := s.client.Load().(promtail.Client)
+	s.client.Store(newClient)
+	lokiBatchSize.Set(float64(cfg.BatchEntriesNumber))
+
+	if old != nil {
+		go old.Shutdown()
+	}
+	return nil
+}
+
+func (s *promtailSink) Log(level LogLevel, msg string, fields logFields) error {
+	client, _ := s.client.Load().(promtail.Client)
+	if client == nil {
+		return errors.New("promtail sink: no client configured")
+	}
+
+	line, err := marshalLogLine(level, msg, fields)
+	if err != nil {
+		return err
+	}
+
+	switch level {
+	case LevelDebug:
+		client.Debugf(line)
+	case LevelWarn:
+		client.Warnf(line)
+	case LevelError:
+		client.Errorf(line)
+	default:
+		client.Infof(line)
+	}
+	return nil
+}
+
+func (s *promtailSink) Shutdown() {
+	if client, _ := s.client.Load().(promtail.Client); client != nil {
+		client.Shutdown()
+	}
+}
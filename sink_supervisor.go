@@ -0,0 +1,84 @@
+package main
+
+import (
+	"log"
+	"sync/atomic"
+	"time"
+)
+
+// supervisingSink wraps a primary sink and diverts to a fallback after
+// maxFailures consecutive Log errors, so an outage of the primary transport
+// (e.g. Loki being down) doesn't drop logs or block request handling. It
+// periodically retries the primary in the background and switches back once
+// a health-check log succeeds.
+type supervisingSink struct {
+	primary  LogSink
+	fallback LogSink
+
+	maxFailures   int32
+	failureCount  int32
+	usingFallback int32 // 0 or 1, via atomic
+
+	retryInterval time.Duration
+	stopCh        chan struct{}
+}
+
+func newSupervisingSink(primary, fallback LogSink, maxFailures int32, retryInterval time.Duration) *supervisingSink {
+	s := &supervisingSink{
+		primary:       primary,
+		fallback:      fallback,
+		maxFailures:   maxFailures,
+		retryInterval: retryInterval,
+		stopCh:        make(chan struct{}),
+	}
+	go s.retryLoop()
+	return s
+}
+
+func (s *supervisingSink) Log(level LogLevel, msg string, fields logFields) error {
+	if atomic.LoadInt32(&s.usingFallback) == 1 {
+		return s.fallback.Log(level, msg, fields)
+	}
+
+	if err := s.primary.Log(level, msg, fields); err != nil {
+		if atomic.AddInt32(&s.failureCount, 1) >= s.maxFailures {
+			atomic.StoreInt32(&s.usingFallback, 1)
+			lokiSinkFallbackActive.Set(1)
+			log.Printf("log sink: primary failed %d times in a row (%v), diverting to fallback", s.maxFailures, err)
+		}
+		return s.fallback.Log(level, msg, fields)
+	}
+
+	atomic.StoreInt32(&s.failureCount, 0)
+	return nil
+}
+
+// retryLoop periodically re-tries the primary sink while the fallback is
+// active, and switches back as soon as one health-check log line succeeds.
+func (s *supervisingSink) retryLoop() {
+	ticker := time.NewTicker(s.retryInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stopCh:
+			return
+		case <-ticker.C:
+			if atomic.LoadInt32(&s.usingFallback) != 1 {
+				continue
+			}
+			if err := s.primary.Log(LevelDebug, "log sink health check", logFields{"check": "primary_recovery"}); err == nil {
+				atomic.StoreInt32(&s.usingFallback, 0)
+				atomic.StoreInt32(&s.failureCount, 0)
+				lokiSinkFallbackActive.Set(0)
+				log.Printf("log sink: primary recovered, switching back from fallback")
+			}
+		}
+	}
+}
+
+func (s *supervisingSink) Shutdown() {
+	close(s.stopCh)
+	s.primary.Shutdown()
+	s.fallback.Shutdown()
+}
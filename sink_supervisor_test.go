@@ -0,0 +1,90 @@
+package main
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// fakeSink is a LogSink whose Log behavior is toggled from the test via
+// failing, and which counts how many lines it received.
+type fakeSink struct {
+	failing atomic.Bool
+	calls   atomic.Int32
+}
+
+func (f *fakeSink) Log(level LogLevel, msg string, fields logFields) error {
+	f.calls.Add(1)
+	if f.failing.Load() {
+		return errors.New("fake sink: forced failure")
+	}
+	return nil
+}
+
+func (f *fakeSink) Shutdown() {}
+
+func TestSupervisingSinkFailoverAndRecovery(t *testing.T) {
+	primary := &fakeSink{}
+	fallback := &fakeSink{}
+
+	s := newSupervisingSink(primary, fallback, 3, 20*time.Millisecond)
+	defer s.Shutdown()
+
+	// Healthy primary: every line goes to primary, none to fallback.
+	if err := s.Log(LevelInfo, "ok", nil); err != nil {
+		t.Fatalf("Log while healthy: %v", err)
+	}
+	if primary.calls.Load() != 1 || fallback.calls.Load() != 0 {
+		t.Fatalf("expected 1 primary call and 0 fallback calls, got primary=%d fallback=%d", primary.calls.Load(), fallback.calls.Load())
+	}
+
+	// Fail the primary for fewer than maxFailures: still on primary.
+	primary.failing.Store(true)
+	for i := 0; i < 2; i++ {
+		_ = s.Log(LevelInfo, "failing", nil)
+	}
+	if atomic.LoadInt32(&s.usingFallback) != 0 {
+		t.Fatal("diverted to fallback before reaching maxFailures")
+	}
+
+	// One more failure crosses maxFailures=3: should divert. The line
+	// itself still succeeds because the (healthy) fallback absorbs it.
+	if err := s.Log(LevelInfo, "failing", nil); err != nil {
+		t.Fatalf("Log on the line that trips the fallback divert: %v", err)
+	}
+	if atomic.LoadInt32(&s.usingFallback) != 1 {
+		t.Fatal("expected supervisor to divert to fallback after 3 consecutive failures")
+	}
+	if got := testutil.ToFloat64(lokiSinkFallbackActive); got != 1 {
+		t.Fatalf("lokiSinkFallbackActive = %v, want 1", got)
+	}
+
+	// While diverted, lines go straight to fallback and skip primary.
+	callsBefore := primary.calls.Load()
+	if err := s.Log(LevelInfo, "diverted", nil); err != nil {
+		t.Fatalf("Log while diverted: %v", err)
+	}
+	if primary.calls.Load() != callsBefore {
+		t.Fatal("primary was called while diverted to fallback")
+	}
+
+	// Recovery: once primary stops failing, the retry loop should switch
+	// back within a couple of retry intervals.
+	primary.failing.Store(false)
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if atomic.LoadInt32(&s.usingFallback) == 0 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if atomic.LoadInt32(&s.usingFallback) != 0 {
+		t.Fatal("supervisor did not recover back to primary after it stopped failing")
+	}
+	if got := testutil.ToFloat64(lokiSinkFallbackActive); got != 0 {
+		t.Fatalf("lokiSinkFallbackActive = %v, want 0 after recovery", got)
+	}
+}